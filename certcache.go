@@ -0,0 +1,107 @@
+package sns
+
+import (
+	"container/list"
+	"crypto/x509"
+	"sync"
+	"time"
+)
+
+// Cache stores parsed SNS signing certificates keyed by their
+// SigningCertURL so VerifyPayload does not have to re-fetch and
+// re-parse the same certificate on every request.
+type Cache interface {
+	Get(url string) (*x509.Certificate, bool)
+	Set(url string, cert *x509.Certificate)
+}
+
+// certCache is the process-wide Cache used by VerifyPayload. It defaults
+// to an in-memory LRU cache and can be replaced with SetCertCache.
+var certCache Cache = NewCertCache(DefaultCertCacheMaxEntries, DefaultCertCacheTTL)
+
+// SetCertCache replaces the process-wide certificate cache used by
+// VerifyPayload and VerifyPayloadWithContext.
+func SetCertCache(c Cache) {
+	certCache = c
+}
+
+// Defaults for the in-memory cache returned by NewCertCache.
+const (
+	DefaultCertCacheMaxEntries = 128
+	DefaultCertCacheTTL        = time.Hour
+)
+
+type certCacheEntry struct {
+	url       string
+	cert      *x509.Certificate
+	expiresAt time.Time
+}
+
+// memCertCache is an in-memory, size-bounded Cache with a fixed TTL per
+// entry and least-recently-used eviction once maxEntries is exceeded.
+type memCertCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+// NewCertCache returns an in-memory Cache that holds at most maxEntries
+// certificates, each valid for ttl after it is stored. A maxEntries of
+// zero or less leaves the number of entries unbounded.
+func NewCertCache(maxEntries int, ttl time.Duration) Cache {
+	return &memCertCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (c *memCertCache) Get(url string) (*x509.Certificate, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*certCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, url)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry.cert, true
+}
+
+func (c *memCertCache) Set(url string, cert *x509.Certificate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[url]; ok {
+		el.Value.(*certCacheEntry).cert = cert
+		el.Value.(*certCacheEntry).expiresAt = time.Now().Add(c.ttl)
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&certCacheEntry{
+		url:       url,
+		cert:      cert,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+	c.entries[url] = el
+
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*certCacheEntry).url)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package sns
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client performs the network calls behind VerifyPayload, Subscribe, and
+// Unsubscribe using a configurable *http.Client, so callers can set
+// timeouts, proxies, mTLS transports, or instrumentation (e.g. an
+// OpenTelemetry-wrapped http.RoundTripper).
+type Client struct {
+	HTTPClient *http.Client
+}
+
+// DefaultClient is the Client used by the Payload methods that are not
+// called on a *Client directly (VerifyPayload, VerifyPayloadWithContext,
+// Subscribe, Unsubscribe).
+var DefaultClient = &Client{
+	HTTPClient: &http.Client{Timeout: 30 * time.Second},
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// VerifyPayload verifies that payload came from SNS, fetching (and
+// caching) its signing certificate with ctx and c's HTTPClient.
+func (c *Client) VerifyPayload(ctx context.Context, payload *Payload) error {
+	payloadSignature, err := base64.StdEncoding.DecodeString(payload.Signature)
+	if err != nil {
+		return err
+	}
+
+	certURL, err := url.Parse(payload.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	if certURL.Scheme != "https" {
+		return fmt.Errorf("url should be using https")
+	}
+
+	if !certURLPattern.MatchString(payload.SigningCertURL) {
+		return fmt.Errorf("certificate url is not a valid SNS signing certificate url")
+	}
+
+	parsedCertificate, err := c.fetchSigningCertificate(ctx, payload.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	return parsedCertificate.CheckSignature(payload.SignatureAlgorithm(), payload.BuildSignature(), payloadSignature)
+}
+
+func (c *Client) fetchSigningCertificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if cert, ok := certCache.Get(certURL); ok {
+		return cert, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedPem, _ := pem.Decode(body)
+	if decodedPem == nil {
+		return nil, errors.New("The decoded PEM file was empty!")
+	}
+
+	parsedCertificate, err := x509.ParseCertificate(decodedPem.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	certCache.Set(certURL, parsedCertificate)
+
+	return parsedCertificate, nil
+}
+
+// Subscribe will use the SubscribeURL in a payload to confirm a subscription and return a ConfirmSubscriptionResponse
+func (c *Client) Subscribe(ctx context.Context, payload *Payload) (ConfirmSubscriptionResponse, error) {
+	var response ConfirmSubscriptionResponse
+	if payload.SubscribeURL == "" {
+		return response, errors.New("Payload does not have a SubscribeURL!")
+	}
+
+	body, err := c.get(ctx, payload.SubscribeURL)
+	if err != nil {
+		return response, err
+	}
+
+	if xmlErr := xml.Unmarshal(body, &response); xmlErr != nil {
+		return response, xmlErr
+	}
+	return response, nil
+}
+
+// Unsubscribe will use the UnsubscribeURL in a payload to cancel a subscription and return a UnsubscribeResponse
+func (c *Client) Unsubscribe(ctx context.Context, payload *Payload) (UnsubscribeResponse, error) {
+	var response UnsubscribeResponse
+
+	body, err := c.get(ctx, payload.UnsubscribeURL)
+	if err != nil {
+		return response, err
+	}
+
+	if xmlErr := xml.Unmarshal(body, &response); xmlErr != nil {
+		return response, xmlErr
+	}
+	return response, nil
+}
+
+func (c *Client) get(ctx context.Context, requestURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,171 @@
+package sns
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Handler is an http.Handler that verifies incoming SNS HTTP(S) POST
+// requests, auto-confirms subscriptions, and dispatches SES notifications
+// to the registered callbacks. A zero Handler with no callbacks set simply
+// verifies every request it receives and acknowledges those that pass.
+type Handler struct {
+	// TopicARNAllowlist, when non-empty, restricts processing to payloads
+	// whose TopicArn is present in the list. Payloads for any other topic
+	// are rejected with http.StatusForbidden.
+	TopicARNAllowlist []string
+
+	OnBounce           func(*AmazonSesBounceNotification)
+	OnComplaint        func(*AmazonSesComplaintNotification)
+	OnDelivery         func(*AmazonSesDeliveryNotification)
+	OnSend             func(*AmazonSesSendNotification)
+	OnReject           func(*AmazonSesRejectNotification)
+	OnOpen             func(*AmazonSesOpenNotification)
+	OnClick            func(*AmazonSesClickNotification)
+	OnRenderingFailure func(*AmazonSesRenderingFailureNotification)
+	OnDeliveryDelay    func(*AmazonSesDeliveryDelayNotification)
+	OnSubscription     func(*AmazonSesSubscriptionNotification)
+
+	// OnSubscribeConfirmed is called after a SubscriptionConfirmation
+	// payload has been auto-confirmed via Payload.Subscribe.
+	OnSubscribeConfirmed func(*Payload)
+	// OnUnsubscribed is called when an UnsubscribeConfirmation is received.
+	OnUnsubscribed func(*Payload)
+	// OnRaw is called for any Notification payload whose Message does not
+	// match a known notificationType/eventType or whose matching callback
+	// is nil, and for any payload type not otherwise handled. It is always
+	// called last, so it never fires
+	// for payloads that were dispatched to a more specific callback.
+	OnRaw func(*Payload)
+}
+
+// ServeHTTP implements http.Handler. It reads and verifies the request
+// body as a Payload, then dispatches it based on Payload.Type and, for
+// notifications, the notificationType/eventType of the decoded Message.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := payload.VerifyPayload(); err != nil {
+		http.Error(w, fmt.Sprintf("invalid payload signature: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if !h.topicAllowed(payload.TopicArn) {
+		http.Error(w, "topic arn not in allowlist", http.StatusForbidden)
+		return
+	}
+
+	switch payload.Type {
+	case "SubscriptionConfirmation":
+		if _, err := payload.Subscribe(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		if h.OnSubscribeConfirmed != nil {
+			h.OnSubscribeConfirmed(&payload)
+		}
+	case "UnsubscribeConfirmation":
+		if h.OnUnsubscribed != nil {
+			h.OnUnsubscribed(&payload)
+		}
+	case "Notification":
+		h.dispatchNotification(&payload)
+	default:
+		if h.OnRaw != nil {
+			h.OnRaw(&payload)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) topicAllowed(topicArn string) bool {
+	if len(h.TopicARNAllowlist) == 0 {
+		return true
+	}
+	for _, allowed := range h.TopicARNAllowlist {
+		if allowed == topicArn {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) dispatchNotification(payload *Payload) {
+	event, err := ParseSESEvent(payload.Message)
+	if err != nil {
+		if h.OnRaw != nil {
+			h.OnRaw(payload)
+		}
+		return
+	}
+
+	switch n := event.(type) {
+	case *AmazonSesBounceNotification:
+		if h.OnBounce != nil {
+			h.OnBounce(n)
+			return
+		}
+	case *AmazonSesComplaintNotification:
+		if h.OnComplaint != nil {
+			h.OnComplaint(n)
+			return
+		}
+	case *AmazonSesDeliveryNotification:
+		if h.OnDelivery != nil {
+			h.OnDelivery(n)
+			return
+		}
+	case *AmazonSesSendNotification:
+		if h.OnSend != nil {
+			h.OnSend(n)
+			return
+		}
+	case *AmazonSesRejectNotification:
+		if h.OnReject != nil {
+			h.OnReject(n)
+			return
+		}
+	case *AmazonSesOpenNotification:
+		if h.OnOpen != nil {
+			h.OnOpen(n)
+			return
+		}
+	case *AmazonSesClickNotification:
+		if h.OnClick != nil {
+			h.OnClick(n)
+			return
+		}
+	case *AmazonSesRenderingFailureNotification:
+		if h.OnRenderingFailure != nil {
+			h.OnRenderingFailure(n)
+			return
+		}
+	case *AmazonSesDeliveryDelayNotification:
+		if h.OnDeliveryDelay != nil {
+			h.OnDeliveryDelay(n)
+			return
+		}
+	case *AmazonSesSubscriptionNotification:
+		if h.OnSubscription != nil {
+			h.OnSubscription(n)
+			return
+		}
+	}
+
+	if h.OnRaw != nil {
+		h.OnRaw(payload)
+	}
+}
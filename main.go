@@ -3,16 +3,10 @@ package sns
 
 import (
 	"bytes"
+	"context"
 	"crypto/x509"
-	"encoding/base64"
 	"encoding/json"
-	"encoding/pem"
 	"encoding/xml"
-	"errors"
-	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
 	"reflect"
 	"regexp"
 	"strings"
@@ -113,8 +107,11 @@ type AmazonSesDelivery struct {
 	RemoteMtaIp          string       `json:"remoteMtaIp"`
 }
 
+// certURLPattern matches the full shape of a genuine SNS signing
+// certificate URL, not just its host, so a valid-looking host paired
+// with an attacker-controlled path (e.g. "/evil.pem") is rejected.
 // https://github.com/robbiet480/go.sns/issues/2
-var hostPattern = regexp.MustCompile(`^sns\.[a-zA-Z0-9\-]{3,}\.amazonaws\.com(\.cn)?$`)
+var certURLPattern = regexp.MustCompile(`(?i)^https://sns\.[a-z0-9\-]+\.amazonaws\.com(\.cn)?/SimpleNotificationService-[a-z0-9]+\.pem$`)
 
 // Payload contains a single POST from SNS
 type Payload struct {
@@ -169,95 +166,23 @@ func (payload *Payload) SignatureAlgorithm() x509.SignatureAlgorithm {
 	return x509.SHA1WithRSA
 }
 
-// VerifyPayload will verify that a payload came from SNS
+// VerifyPayload will verify that a payload came from SNS, using DefaultClient.
 func (payload *Payload) VerifyPayload() error {
-	payloadSignature, err := base64.StdEncoding.DecodeString(payload.Signature)
-	if err != nil {
-		return err
-	}
-
-	certURL, err := url.Parse(payload.SigningCertURL)
-	if err != nil {
-		return err
-	}
-
-	if certURL.Scheme != "https" {
-		return fmt.Errorf("url should be using https")
-	}
-
-	if !hostPattern.Match([]byte(certURL.Host)) {
-		return fmt.Errorf("certificate is located on an invalid domain")
-	}
-
-	resp, err := http.Get(payload.SigningCertURL)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	decodedPem, _ := pem.Decode(body)
-	if decodedPem == nil {
-		return errors.New("The decoded PEM file was empty!")
-	}
-
-	parsedCertificate, err := x509.ParseCertificate(decodedPem.Bytes)
-	if err != nil {
-		return err
-	}
+	return DefaultClient.VerifyPayload(context.Background(), payload)
+}
 
-	return parsedCertificate.CheckSignature(payload.SignatureAlgorithm(), payload.BuildSignature(), payloadSignature)
+// VerifyPayloadWithContext behaves like VerifyPayload but threads ctx
+// through the signing certificate fetch, using DefaultClient.
+func (payload *Payload) VerifyPayloadWithContext(ctx context.Context) error {
+	return DefaultClient.VerifyPayload(ctx, payload)
 }
 
-// Subscribe will use the SubscribeURL in a payload to confirm a subscription and return a ConfirmSubscriptionResponse
+// Subscribe will use the SubscribeURL in a payload to confirm a subscription and return a ConfirmSubscriptionResponse, using DefaultClient.
 func (payload *Payload) Subscribe() (ConfirmSubscriptionResponse, error) {
-	var response ConfirmSubscriptionResponse
-	if payload.SubscribeURL == "" {
-		return response, errors.New("Payload does not have a SubscribeURL!")
-	}
-
-	resp, err := http.Get(payload.SubscribeURL)
-	if err != nil {
-		return response, err
-	}
-
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return response, err
-	}
-
-	xmlErr := xml.Unmarshal(body, &response)
-	if xmlErr != nil {
-		return response, xmlErr
-	}
-	return response, nil
+	return DefaultClient.Subscribe(context.Background(), payload)
 }
 
-// Unsubscribe will use the UnsubscribeURL in a payload to confirm a subscription and return a UnsubscribeResponse
+// Unsubscribe will use the UnsubscribeURL in a payload to cancel a subscription and return a UnsubscribeResponse, using DefaultClient.
 func (payload *Payload) Unsubscribe() (UnsubscribeResponse, error) {
-	var response UnsubscribeResponse
-	resp, err := http.Get(payload.UnsubscribeURL)
-	if err != nil {
-		return response, err
-	}
-
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return response, err
-	}
-
-	xmlErr := xml.Unmarshal(body, &response)
-	if xmlErr != nil {
-		return response, xmlErr
-	}
-	return response, nil
+	return DefaultClient.Unsubscribe(context.Background(), payload)
 }
@@ -0,0 +1,200 @@
+package sns
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SES Event Publishing notifications. These are delivered the same way as
+// the legacy feedback notifications (AmazonSesBounceNotification,
+// AmazonSesComplaintNotification, AmazonSesDeliveryNotification) but are
+// keyed by "eventType" rather than "notificationType".
+// https://docs.aws.amazon.com/ses/latest/dg/event-publishing-retrieving-sns-contents.html
+
+type AmazonSesSendNotification struct {
+	EventType string        `json:"eventType"`
+	Send      AmazonSesSend `json:"send"`
+	Mail      AmazonSesMail `json:"mail"`
+}
+
+// AmazonSesSend carries no fields of its own; a Send event is fully
+// described by its enclosing Mail object.
+type AmazonSesSend struct{}
+
+type AmazonSesRejectNotification struct {
+	EventType string          `json:"eventType"`
+	Reject    AmazonSesReject `json:"reject"`
+	Mail      AmazonSesMail   `json:"mail"`
+}
+
+type AmazonSesReject struct {
+	Reason string `json:"reason"`
+}
+
+type AmazonSesOpenNotification struct {
+	EventType string        `json:"eventType"`
+	Open      AmazonSesOpen `json:"open"`
+	Mail      AmazonSesMail `json:"mail"`
+}
+
+type AmazonSesOpen struct {
+	IpAddress string       `json:"ipAddress"`
+	Timestamp JsonDateTime `json:"timestamp"`
+	UserAgent string       `json:"userAgent"`
+}
+
+type AmazonSesClickNotification struct {
+	EventType string         `json:"eventType"`
+	Click     AmazonSesClick `json:"click"`
+	Mail      AmazonSesMail  `json:"mail"`
+}
+
+type AmazonSesClick struct {
+	IpAddress string              `json:"ipAddress"`
+	Timestamp JsonDateTime        `json:"timestamp"`
+	UserAgent string              `json:"userAgent"`
+	Link      string              `json:"link"`
+	LinkTags  map[string][]string `json:"linkTags"`
+}
+
+type AmazonSesRenderingFailureNotification struct {
+	EventType string                    `json:"eventType"`
+	Failure   AmazonSesRenderingFailure `json:"failure"`
+	Mail      AmazonSesMail             `json:"mail"`
+}
+
+type AmazonSesRenderingFailure struct {
+	TemplateName string `json:"templateName"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+type AmazonSesDeliveryDelayNotification struct {
+	EventType     string                 `json:"eventType"`
+	DeliveryDelay AmazonSesDeliveryDelay `json:"deliveryDelay"`
+	Mail          AmazonSesMail          `json:"mail"`
+}
+
+type AmazonSesDeliveryDelay struct {
+	DelayType         string                      `json:"delayType"`
+	DelayedRecipients []AmazonSesDelayedRecipient `json:"delayedRecipients"`
+	ExpirationTime    JsonDateTime                `json:"expirationTime"`
+	ReportingMTA      string                      `json:"reportingMTA"`
+}
+
+type AmazonSesDelayedRecipient struct {
+	EmailAddress   string `json:"emailAddress"`
+	Status         string `json:"status"`
+	DiagnosticCode string `json:"diagnosticCode"`
+}
+
+type AmazonSesSubscriptionNotification struct {
+	EventType    string                `json:"eventType"`
+	Subscription AmazonSesSubscription `json:"subscription"`
+	Mail         AmazonSesMail         `json:"mail"`
+}
+
+type AmazonSesSubscription struct {
+	ContactList             string                           `json:"contactList"`
+	Timestamp               JsonDateTime                     `json:"timestamp"`
+	Source                  string                           `json:"source"`
+	SubscriptionPreferences AmazonSesSubscriptionPreferences `json:"subscriptionPreferences"`
+}
+
+type AmazonSesSubscriptionPreferences struct {
+	UnsubscribeAll   bool                       `json:"unsubscribeAll"`
+	TopicPreferences []AmazonSesTopicPreference `json:"topicPreferences"`
+}
+
+type AmazonSesTopicPreference struct {
+	Topic                   string `json:"topic"`
+	TopicSubscriptionStatus string `json:"topicSubscriptionStatus"`
+}
+
+// sesEventKind is used to peek at a decoded SNS Payload.Message enough to
+// pick the concrete type to unmarshal it into.
+type sesEventKind struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+}
+
+// ParseSESEvent decodes msg (an SNS Payload.Message body) into the
+// concrete SES notification type indicated by its notificationType (for
+// the legacy Bounce/Complaint/Delivery feedback notifications) or
+// eventType (for SES Event Publishing). It returns an error if msg does
+// not match any known type.
+func ParseSESEvent(msg string) (interface{}, error) {
+	var kind sesEventKind
+	if err := json.Unmarshal([]byte(msg), &kind); err != nil {
+		return nil, err
+	}
+
+	message := []byte(msg)
+
+	switch kind.NotificationType {
+	case "Bounce":
+		var n AmazonSesBounceNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case "Complaint":
+		var n AmazonSesComplaintNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case "Delivery":
+		var n AmazonSesDeliveryNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	}
+
+	switch kind.EventType {
+	case "Send":
+		var n AmazonSesSendNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case "Reject":
+		var n AmazonSesRejectNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case "Open":
+		var n AmazonSesOpenNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case "Click":
+		var n AmazonSesClickNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case "Rendering Failure":
+		var n AmazonSesRenderingFailureNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case "DeliveryDelay":
+		var n AmazonSesDeliveryDelayNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case "Subscription":
+		var n AmazonSesSubscriptionNotification
+		if err := json.Unmarshal(message, &n); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	}
+
+	return nil, fmt.Errorf("sns: unrecognized SES notification/event type %q/%q", kind.NotificationType, kind.EventType)
+}
@@ -0,0 +1,76 @@
+package sns
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultMaxPayloadAge is the MaxAge used by VerifyPayloadWithOptions
+// when VerifyPayloadOptions.MaxAge is left at its zero value, matching
+// AWS's own guidance on how long an SNS message should be considered
+// valid.
+const DefaultMaxPayloadAge = time.Hour
+
+// maxFutureSkew bounds how far into the future Payload.Timestamp may be,
+// to tolerate clock drift between the sender and this host.
+const maxFutureSkew = 5 * time.Minute
+
+// VerifyPayloadOptions configures the replay protection performed by
+// VerifyPayloadWithOptions, in addition to the signature check already
+// performed by VerifyPayload.
+type VerifyPayloadOptions struct {
+	// MaxAge rejects payloads whose Timestamp is older than this
+	// duration. Defaults to DefaultMaxPayloadAge when zero.
+	MaxAge time.Duration
+
+	// Clock returns the current time and defaults to time.Now. It exists
+	// so tests can verify MaxAge handling without depending on the
+	// wall clock.
+	Clock func() time.Time
+
+	// MessageIDSeen, if set, is called with Payload.MessageId and should
+	// return true if that message has already been processed, letting
+	// callers plug in a dedup store (Redis, etc.) to reject replays
+	// within the MaxAge window.
+	MessageIDSeen func(id string) bool
+}
+
+// VerifyPayloadWithOptions verifies payload's signature via
+// VerifyPayloadWithContext and additionally rejects payloads that are
+// too old, dated in the future, or have already been seen according to
+// opts.MessageIDSeen.
+func (payload *Payload) VerifyPayloadWithOptions(ctx context.Context, opts VerifyPayloadOptions) error {
+	if err := payload.VerifyPayloadWithContext(ctx); err != nil {
+		return err
+	}
+
+	maxAge := opts.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultMaxPayloadAge
+	}
+
+	clock := opts.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, payload.Timestamp)
+	if err != nil {
+		return fmt.Errorf("sns: invalid payload timestamp: %w", err)
+	}
+
+	now := clock()
+	if age := now.Sub(timestamp); age > maxAge {
+		return fmt.Errorf("sns: payload timestamp %s is older than MaxAge %s", timestamp, maxAge)
+	}
+	if skew := timestamp.Sub(now); skew > maxFutureSkew {
+		return fmt.Errorf("sns: payload timestamp %s is too far in the future", timestamp)
+	}
+
+	if opts.MessageIDSeen != nil && opts.MessageIDSeen(payload.MessageId) {
+		return fmt.Errorf("sns: payload %s has already been processed", payload.MessageId)
+	}
+
+	return nil
+}